@@ -0,0 +1,53 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+)
+
+// ctxKey is an unexported type so the default context key can never
+// collide with a key defined in another package.
+type ctxKey struct{ name string }
+
+// defaultContextKey is the context key used by NewContext, FromContext, and
+// NewSaveHandlerRequestContext(nil).
+var defaultContextKey = &ctxKey{"requestid"}
+
+// SaveHandler RequestContext
+type saveHandlerRequestContext struct {
+	key any
+}
+
+// NewSaveHandlerRequestContext returns an IDSaveHandler that stores id on
+// the request's context via r.WithContext(context.WithValue(...)), avoiding
+// the gorilla/context global map (and the context.ClearHandler wrapping,
+// and the leak when it's forgotten) that NewSaveHandlerContext relies on.
+// Because the updated request carries a new context, requestIDInjector
+// passes it on to the next handler in the chain.
+//
+// key is passed straight through to context.WithValue; pass nil to use the
+// same key FromContext/NewContext use.
+func NewSaveHandlerRequestContext(key any) IDSaveHandler {
+	if key == nil {
+		key = defaultContextKey
+	}
+	return &saveHandlerRequestContext{key}
+}
+
+func (s *saveHandlerRequestContext) SaveID(rw http.ResponseWriter, r *http.Request, id string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), s.key, id))
+}
+
+// NewContext returns a copy of ctx carrying id under the package's default
+// context key.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, defaultContextKey, id)
+}
+
+// FromContext extracts the request ID stored under the package's default
+// context key, e.g. by an IDSaveHandler built with
+// NewSaveHandlerRequestContext(nil). ok is false if no ID is present.
+func FromContext(ctx context.Context) (id string, ok bool) {
+	id, ok = ctx.Value(defaultContextKey).(string)
+	return id, ok
+}
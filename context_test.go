@@ -0,0 +1,76 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewContextFromContextRoundTrip(t *testing.T) {
+	ctx := NewContext(context.Background(), "abc-123")
+
+	id, ok := FromContext(ctx)
+	if !ok || id != "abc-123" {
+		t.Fatalf("FromContext() = (%q, %v), want (\"abc-123\", true)", id, ok)
+	}
+}
+
+func TestFromContextMissing(t *testing.T) {
+	if id, ok := FromContext(context.Background()); ok {
+		t.Fatalf("FromContext() = (%q, true), want ok=false on an empty context", id)
+	}
+}
+
+func TestSaveHandlerRequestContextMutatesRequest(t *testing.T) {
+	h := NewSaveHandlerRequestContext(nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	rw := httptest.NewRecorder()
+
+	out := h.SaveID(rw, r, "abc-123")
+
+	if id, ok := FromContext(out.Context()); !ok || id != "abc-123" {
+		t.Fatalf("FromContext(out.Context()) = (%q, %v), want (\"abc-123\", true)", id, ok)
+	}
+	if id, ok := FromContext(r.Context()); ok {
+		t.Fatalf("original request's context was mutated in place: got %q", id)
+	}
+}
+
+func TestSaveHandlerRequestContextCustomKey(t *testing.T) {
+	type key struct{}
+	h := NewSaveHandlerRequestContext(key{})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	rw := httptest.NewRecorder()
+
+	out := h.SaveID(rw, r, "abc-123")
+
+	if got := out.Context().Value(key{}); got != "abc-123" {
+		t.Errorf("out.Context().Value(key{}) = %v, want %q", got, "abc-123")
+	}
+	// A custom key must not also satisfy the package's default lookup.
+	if _, ok := FromContext(out.Context()); ok {
+		t.Error("FromContext() found a value stored under a custom key")
+	}
+}
+
+func TestGetRequestIDPrefersContext(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(DefaultIDHeader, "from-header")
+	r = r.WithContext(NewContext(r.Context(), "from-context"))
+
+	if got := GetRequestID(r); got != "from-context" {
+		t.Errorf("GetRequestID() = %q, want the context value to take priority", got)
+	}
+}
+
+func TestGetRequestIDFallsBackToHeader(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(DefaultIDHeader, "from-header")
+
+	if got := GetRequestID(r); got != "from-header" {
+		t.Errorf("GetRequestID() = %q, want %q", got, "from-header")
+	}
+}
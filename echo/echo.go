@@ -0,0 +1,24 @@
+// Package echo adapts the requestid Source/Save/PostProcessor pipeline to
+// the echo web framework. It is a separate module path so importing
+// requestid itself never pulls in echo.
+package echo
+
+import (
+	echo "github.com/labstack/echo/v4"
+
+	requestid "github.com/urakozz/go-requestid-middleware"
+)
+
+// NewMiddleware returns an echo.MiddlewareFunc running the same pipeline as
+// requestid.RequestIDInjector.
+func NewMiddleware(o *requestid.IDInjectorOptions) echo.MiddlewareFunc {
+	injector := requestid.NewInjector(o)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r, _ := injector.Inject(c.Response(), c.Request())
+			c.SetRequest(r)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package echo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	echo "github.com/labstack/echo/v4"
+
+	requestid "github.com/urakozz/go-requestid-middleware"
+)
+
+func TestNewMiddlewareSetsMutatedRequest(t *testing.T) {
+	e := echo.New()
+	e.Use(NewMiddleware(&requestid.IDInjectorOptions{
+		IDSaveHandler: requestid.NewSaveHandlerRequestContext(nil),
+	}))
+
+	var gotID string
+	var gotOK bool
+	e.GET("/", func(c echo.Context) error {
+		gotID, gotOK = requestid.FromContext(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if !gotOK || gotID == "" {
+		t.Fatalf("handler saw (%q, %v), want a non-empty id propagated via context", gotID, gotOK)
+	}
+}
@@ -0,0 +1,180 @@
+package requestid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	mathrand "math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ksuidEpoch is the KSUID custom epoch (2014-05-13T16:53:20Z), in
+	// seconds since the Unix epoch.
+	ksuidEpoch = 1400000000
+
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// monotonicBase36Width is wide enough to hold any non-negative int64
+	// (math.MaxInt64 is 13 digits in base36) zero-padded, so two draws can
+	// be concatenated without one value's digits bleeding into the other's.
+	monotonicBase36Width = 13
+)
+
+type (
+	uuidV4Generator struct{}
+
+	uuidV7Generator struct{}
+
+	ksuidGenerator struct{}
+
+	monotonicIDGenerator struct {
+		prefix string
+		mu     sync.Mutex
+		a, b   mathrand.Source
+	}
+)
+
+// NewUUIDv4Generator returns an IDGenerator producing random (version 4)
+// UUIDs per RFC 4122.
+func NewUUIDv4Generator() IDGenerator {
+	return &uuidV4Generator{}
+}
+
+func (g *uuidV4Generator) Generate() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+	return formatUUID(b)
+}
+
+// NewUUIDv7Generator returns an IDGenerator producing time-ordered (version
+// 7) UUIDs: a 48-bit big-endian Unix millisecond timestamp in the high bits
+// followed by the version/variant fields and 74 bits of randomness. Because
+// the timestamp sorts lexicographically, UUIDv7 values are naturally
+// ordered by creation time.
+func NewUUIDv7Generator() IDGenerator {
+	return &uuidV7Generator{}
+}
+
+func (g *uuidV7Generator) Generate() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+
+	ms := uint64(time.Now().UnixMilli())
+	b[0] = byte(ms >> 40)
+	b[1] = byte(ms >> 32)
+	b[2] = byte(ms >> 24)
+	b[3] = byte(ms >> 16)
+	b[4] = byte(ms >> 8)
+	b[5] = byte(ms)
+
+	b[6] = (b[6] & 0x0f) | 0x70 // version 7
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return formatUUID(b)
+}
+
+func formatUUID(b [16]byte) string {
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:36], b[10:16])
+	return string(buf)
+}
+
+// NewKSUIDGenerator returns an IDGenerator producing K-Sortable Unique
+// IDentifiers: a 4-byte timestamp (seconds since the KSUID epoch) followed
+// by 16 random bytes, base62-encoded to a fixed 27 characters. Like KSUID
+// proper, values sort lexicographically by creation time.
+func NewKSUIDGenerator() IDGenerator {
+	return &ksuidGenerator{}
+}
+
+func (g *ksuidGenerator) Generate() string {
+	var payload [20]byte
+	ts := uint32(time.Now().Unix() - ksuidEpoch)
+	payload[0] = byte(ts >> 24)
+	payload[1] = byte(ts >> 16)
+	payload[2] = byte(ts >> 8)
+	payload[3] = byte(ts)
+	if _, err := rand.Read(payload[4:]); err != nil {
+		return ""
+	}
+	return base62EncodePadded(payload[:], 27)
+}
+
+func base62EncodePadded(b []byte, width int) string {
+	n := new(big.Int).SetBytes(b)
+	base := big.NewInt(62)
+	mod := new(big.Int)
+
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		out = append(out, base62Alphabet[mod.Int64()])
+	}
+	for len(out) < width {
+		out = append(out, base62Alphabet[0])
+	}
+	// reverse, digits were produced least-significant first
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// NewMonotonicIDGenerator returns an IDGenerator producing prefix+<id>
+// values (prefix is prepended as is, with no separator) where <id> is the concatenation of two independent math/rand
+// sources, each zero-padded to monotonicBase36Width base36 digits before
+// joining, then truncated to 20 characters. The padding keeps the join
+// unambiguous -- without it, e.g. (1, 36) and (37, 0) both format to "110".
+// Both sources are guarded by a mutex so concurrent callers can never
+// observe the same pair of draws, guaranteeing uniqueness without relying
+// on time.Now().UnixNano() (which collides under high concurrency). Mirrors
+// the approach used by Arvados' request ID generator.
+func NewMonotonicIDGenerator(prefix string) IDGenerator {
+	now := time.Now().UnixNano()
+	return &monotonicIDGenerator{
+		prefix: prefix,
+		a:      mathrand.NewSource(now),
+		b:      mathrand.NewSource(now ^ 0x5DEECE66D),
+	}
+}
+
+func (g *monotonicIDGenerator) Generate() string {
+	g.mu.Lock()
+	a, b := g.a.Int63(), g.b.Int63()
+	g.mu.Unlock()
+
+	id := formatBase36Padded(a, monotonicBase36Width) + formatBase36Padded(b, monotonicBase36Width)
+	if len(id) > 20 {
+		id = id[:20]
+	}
+	if g.prefix == "" {
+		return id
+	}
+	return g.prefix + id
+}
+
+func formatBase36Padded(n int64, width int) string {
+	s := strconv.FormatInt(n, 36)
+	if len(s) < width {
+		s = strings.Repeat("0", width-len(s)) + s
+	}
+	return s
+}
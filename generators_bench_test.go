@@ -0,0 +1,51 @@
+package requestid
+
+import "testing"
+
+func BenchmarkRandomIDGenerator(b *testing.B) {
+	g := NewRandomIDGenerator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
+
+func BenchmarkTimestampIDGenerator(b *testing.B) {
+	g := NewTimestampIDGenerator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
+
+func BenchmarkUUIDv4Generator(b *testing.B) {
+	g := NewUUIDv4Generator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
+
+func BenchmarkUUIDv7Generator(b *testing.B) {
+	g := NewUUIDv7Generator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
+
+func BenchmarkKSUIDGenerator(b *testing.B) {
+	g := NewKSUIDGenerator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
+
+func BenchmarkMonotonicIDGenerator(b *testing.B) {
+	g := NewMonotonicIDGenerator("req_")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
@@ -0,0 +1,62 @@
+package requestid
+
+import (
+	"regexp"
+	"testing"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+func TestUUIDv4GeneratorFormat(t *testing.T) {
+	id := NewUUIDv4Generator().Generate()
+	if !uuidPattern.MatchString(id) {
+		t.Fatalf("Generate() = %q, want RFC 4122 UUID", id)
+	}
+	if id[14] != '4' {
+		t.Errorf("version nibble = %q, want '4'", id[14])
+	}
+	if variant := id[19]; variant != '8' && variant != '9' && variant != 'a' && variant != 'b' {
+		t.Errorf("variant nibble = %q, want one of 8/9/a/b", variant)
+	}
+}
+
+func TestUUIDv7GeneratorFormat(t *testing.T) {
+	id := NewUUIDv7Generator().Generate()
+	if !uuidPattern.MatchString(id) {
+		t.Fatalf("Generate() = %q, want RFC 4122 UUID", id)
+	}
+	if id[14] != '7' {
+		t.Errorf("version nibble = %q, want '7'", id[14])
+	}
+}
+
+func TestKSUIDGeneratorFormat(t *testing.T) {
+	id := NewKSUIDGenerator().Generate()
+	if len(id) != 27 {
+		t.Fatalf("len(Generate()) = %d, want 27", len(id))
+	}
+}
+
+func TestMonotonicIDGeneratorNoCollisionsOnFixedWidthJoin(t *testing.T) {
+	// Regression test: concatenating two unpadded base36 values is
+	// ambiguous, e.g. FormatInt(1,36)+FormatInt(36,36) == "1"+"10" == "110"
+	// == FormatInt(37,36)+FormatInt(0,36) == "11"+"0". Padding each field
+	// to a fixed width before joining must keep these distinct.
+	a := formatBase36Padded(1, monotonicBase36Width) + formatBase36Padded(36, monotonicBase36Width)
+	b := formatBase36Padded(37, monotonicBase36Width) + formatBase36Padded(0, monotonicBase36Width)
+	if a == b {
+		t.Fatalf("padded join collided: %q == %q", a, b)
+	}
+}
+
+func TestMonotonicIDGeneratorUnique(t *testing.T) {
+	g := NewMonotonicIDGenerator("req_")
+	seen := make(map[string]bool)
+	for i := 0; i < 10000; i++ {
+		id := g.Generate()
+		if seen[id] {
+			t.Fatalf("duplicate id %q after %d draws", id, i)
+		}
+		seen[id] = true
+	}
+}
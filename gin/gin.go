@@ -0,0 +1,22 @@
+// Package gin adapts the requestid Source/Save/PostProcessor pipeline to
+// the gin web framework. It is a separate module path so importing
+// requestid itself never pulls in gin.
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	requestid "github.com/urakozz/go-requestid-middleware"
+)
+
+// NewMiddleware returns a gin.HandlerFunc running the same pipeline as
+// requestid.RequestIDInjector.
+func NewMiddleware(o *requestid.IDInjectorOptions) gin.HandlerFunc {
+	injector := requestid.NewInjector(o)
+
+	return func(c *gin.Context) {
+		r, _ := injector.Inject(c.Writer, c.Request)
+		c.Request = r
+		c.Next()
+	}
+}
@@ -0,0 +1,35 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	requestid "github.com/urakozz/go-requestid-middleware"
+)
+
+func TestNewMiddlewareSetsMutatedRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(NewMiddleware(&requestid.IDInjectorOptions{
+		IDSaveHandler: requestid.NewSaveHandlerRequestContext(nil),
+	}))
+
+	var gotID string
+	var gotOK bool
+	r.GET("/", func(c *gin.Context) {
+		gotID, gotOK = requestid.FromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if !gotOK || gotID == "" {
+		t.Fatalf("handler saw (%q, %v), want a non-empty id propagated via context", gotID, gotOK)
+	}
+}
@@ -0,0 +1,45 @@
+package requestid
+
+import "net/http"
+
+// Injector is the framework-agnostic core that RequestIDInjector,
+// NewHTTPMiddleware and the requestid/echo and requestid/gin adapters all
+// wrap. It runs the source/generate/save/post-process pipeline for a
+// single request and returns the (possibly mutated, e.g. by a
+// context-based IDSaveHandler) *http.Request together with the ID that was
+// used.
+type Injector interface {
+	Inject(rw http.ResponseWriter, r *http.Request) (*http.Request, string)
+}
+
+// NewInjector builds the Source/Save/PostProcessor pipeline described by o
+// without tying it to negroni. Adapters for frameworks other than the ones
+// already shipped can be built on top of it.
+func NewInjector(o *IDInjectorOptions) Injector {
+	middleware := &requestIDInjector{o.IDGenerator, o.IDSource, o.IDSaveHandler, o.IDPostProcessor}
+	middleware.applyDefaults()
+	return middleware
+}
+
+func (v *requestIDInjector) Inject(rw http.ResponseWriter, r *http.Request) (*http.Request, string) {
+	id := v.idSource.GetID(r)
+	if id == "" {
+		id = v.idGenerator.Generate()
+	}
+	r = v.idSaveHandler.SaveID(rw, r, id)
+	v.idPostProcessor.Process(rw, r, id)
+	return r, id
+}
+
+// NewHTTPMiddleware adapts the same pipeline into a plain
+// func(http.Handler) http.Handler, for stdlib, chi, gorilla/mux, or any
+// router that speaks that convention.
+func NewHTTPMiddleware(o *IDInjectorOptions) func(http.Handler) http.Handler {
+	injector := NewInjector(o)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			r, _ = injector.Inject(rw, r)
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
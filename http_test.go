@@ -0,0 +1,67 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewInjectorGeneratesWhenSourceEmpty(t *testing.T) {
+	injector := NewInjector(&IDInjectorOptions{
+		IDGenerator:     NewRandomIDGenerator(),
+		IDSource:        NewSourceHeader(DefaultIDHeader),
+		IDSaveHandler:   NewSaveHandlerHeader(DefaultIDHeader),
+		IDPostProcessor: NewPostProcessorHeader(DefaultIDHeader),
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	rw := httptest.NewRecorder()
+
+	out, id := injector.Inject(rw, r)
+	if id == "" {
+		t.Fatal("Inject() returned an empty id")
+	}
+	if got := out.Header.Get(DefaultIDHeader); got != id {
+		t.Errorf("request header %s = %q, want %q", DefaultIDHeader, got, id)
+	}
+	if got := rw.Header().Get(DefaultIDHeader); got != id {
+		t.Errorf("response header %s = %q, want %q", DefaultIDHeader, got, id)
+	}
+}
+
+func TestNewInjectorHonorsSource(t *testing.T) {
+	injector := NewInjector(&IDInjectorOptions{
+		IDGenerator:     NewRandomIDGenerator(),
+		IDSource:        NewSourceHeader(DefaultIDHeader),
+		IDSaveHandler:   NewSaveHandlerHeader(DefaultIDHeader),
+		IDPostProcessor: NewPostProcessorHeader(DefaultIDHeader),
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(DefaultIDHeader, "client-id")
+	rw := httptest.NewRecorder()
+
+	_, id := injector.Inject(rw, r)
+	if id != "client-id" {
+		t.Errorf("Inject() id = %q, want %q", id, "client-id")
+	}
+}
+
+func TestNewHTTPMiddlewarePassesMutatedRequestDownstream(t *testing.T) {
+	mw := NewHTTPMiddleware(&IDInjectorOptions{
+		IDSaveHandler: NewSaveHandlerRequestContext(nil),
+	})
+
+	var gotID string
+	var gotOK bool
+	handler := mw(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		gotID, gotOK = FromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	if !gotOK || gotID == "" {
+		t.Fatalf("downstream handler saw (%q, %v), want a non-empty id propagated via context", gotID, gotOK)
+	}
+}
@@ -29,9 +29,12 @@ type (
 		GetID(r *http.Request) string
 	}
 
-	// IDSaveHandler (E.g.: header, context, custom)
+	// IDSaveHandler (E.g.: header, context, custom). It returns the
+	// (possibly mutated) *http.Request so implementations that need to
+	// carry the ID on the request's context can hand back the replacement
+	// produced by r.WithContext.
 	IDSaveHandler interface {
-		SaveID(rw http.ResponseWriter, r *http.Request, id string)
+		SaveID(rw http.ResponseWriter, r *http.Request, id string) *http.Request
 	}
 
 	// IDPostProcessor (E.g.: header, custom)
@@ -78,8 +81,14 @@ func NewRequestIDInjector(o *IDInjectorOptions) RequestIDInjector {
 	return middleware
 }
 
-// GetRequestID extracts command ID from the request header.
+// GetRequestID extracts the request ID, preferring the one stored on the
+// request's context (see NewSaveHandlerRequestContext) and falling back to
+// the DefaultIDHeader request header for callers still using
+// NewSaveHandlerHeader.
 func GetRequestID(r *http.Request) string {
+	if id, ok := FromContext(r.Context()); ok {
+		return id
+	}
 	return r.Header.Get(DefaultIDHeader)
 }
 
@@ -94,14 +103,7 @@ type requestIDInjector struct {
 }
 
 func (v *requestIDInjector) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-
-	id := v.idSource.GetID(r)
-	if id == "" {
-		id = v.idGenerator.Generate()
-	}
-	v.idSaveHandler.SaveID(rw, r, id)
-	v.idPostProcessor.Process(rw, r, id)
-
+	r, _ = v.Inject(rw, r)
 	next(rw, r)
 }
 
@@ -110,7 +112,7 @@ func (v *requestIDInjector) applyDefaults(){
 		v.idGenerator = NewTimestampIDGenerator()
 	}
 	if v.idSource == nil {
-		v.idSource = NewSourceHeader(DefaultIDHeader)
+		v.idSource = NewValidatedSource(NewSourceHeader(DefaultIDHeader), NewDefaultIDValidator(0), nil)
 	}
 	if v.idSaveHandler == nil {
 		v.idSaveHandler = NewSaveHandlerHeader(DefaultIDHeader)
@@ -158,7 +160,11 @@ func (s *sourceHeader) GetID(r *http.Request) string {
 	return r.Header.Get(s.header)
 }
 
-// NewSourceHeader returns new sourceHeader
+// NewSourceHeader returns new sourceHeader. The returned IDSource trusts
+// the header verbatim -- wrap it with NewValidatedSource when header is
+// client-controlled (as DefaultIDHeader is) to reject CRLFs, control
+// characters, or oversized values before they reach logs or response
+// headers.
 func NewSourceHeader(header string) IDSource {
 	return &sourceHeader{header}
 }
@@ -182,8 +188,9 @@ type saveHandlerHeader struct{
 	header string
 }
 
-func (s *saveHandlerHeader) SaveID(rw http.ResponseWriter, r *http.Request, id string) {
+func (s *saveHandlerHeader) SaveID(rw http.ResponseWriter, r *http.Request, id string) *http.Request {
 	r.Header.Set(s.header, id)
+	return r
 }
 // NewSaveHandlerHeader returns new saveHandlerHeader (IDSaveHandler interface)
 func NewSaveHandlerHeader(header string) IDSaveHandler {
@@ -195,8 +202,9 @@ type saveHandlerContext struct{
 	field interface{}
 }
 
-func (s *saveHandlerContext) SaveID(rw http.ResponseWriter, r *http.Request, id string) {
+func (s *saveHandlerContext) SaveID(rw http.ResponseWriter, r *http.Request, id string) *http.Request {
 	context.Set(r, s.field, id)
+	return r
 }
 
 // NewSaveHandlerContext returns new saveHandlerContext (IDSaveHandler interface)
@@ -206,15 +214,15 @@ func NewSaveHandlerContext(field interface{}) IDSaveHandler {
 
 // SaveHandler Custom
 type saveHandlerCustom struct{
-	fn func(rw http.ResponseWriter, r *http.Request, id string)
+	fn func(rw http.ResponseWriter, r *http.Request, id string) *http.Request
 }
 
-func (s *saveHandlerCustom) SaveID(rw http.ResponseWriter, r *http.Request, id string) {
-	s.fn(rw, r, id)
+func (s *saveHandlerCustom) SaveID(rw http.ResponseWriter, r *http.Request, id string) *http.Request {
+	return s.fn(rw, r, id)
 }
 
 // NewSaveHandlerCustom returns new saveHandlerCustom (IDSaveHandler interface)
-func NewSaveHandlerCustom(fn func(rw http.ResponseWriter, r *http.Request, id string)) IDSaveHandler {
+func NewSaveHandlerCustom(fn func(rw http.ResponseWriter, r *http.Request, id string) *http.Request) IDSaveHandler {
 	return &saveHandlerCustom{fn}
 }
 
@@ -223,7 +231,7 @@ type postProcessorHeader struct {
 	header string
 }
 func (p *postProcessorHeader) Process(rw http.ResponseWriter, r *http.Request, id string) {
-	rw.Header()[p.header] = []string{id}
+	rw.Header().Set(p.header, id)
 }
 // NewPostProcessorHeader returns new postProcessorHeader (IDPostProcessor interface)
 func NewPostProcessorHeader(field string) IDPostProcessor {
@@ -0,0 +1,40 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+
+	requestid "github.com/urakozz/go-requestid-middleware"
+)
+
+// requestIDHandler
+type requestIDHandler struct {
+	next slog.Handler
+}
+
+// WithRequestIDAttr wraps h so that every record logged through a context
+// carrying a request ID (see requestid.NewContext / requestid.FromContext)
+// automatically gets a request_id attribute, without every log call at the
+// call site needing to add it by hand.
+func WithRequestIDAttr(h slog.Handler) slog.Handler {
+	return &requestIDHandler{h}
+}
+
+func (h *requestIDHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *requestIDHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id, ok := requestid.FromContext(ctx); ok {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &requestIDHandler{h.next.WithAttrs(attrs)}
+}
+
+func (h *requestIDHandler) WithGroup(name string) slog.Handler {
+	return &requestIDHandler{h.next.WithGroup(name)}
+}
@@ -0,0 +1,42 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	requestid "github.com/urakozz/go-requestid-middleware"
+)
+
+func TestWithRequestIDAttrAddsAttrWhenPresent(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(WithRequestIDAttr(slog.NewJSONHandler(&buf, nil)))
+
+	ctx := requestid.NewContext(context.Background(), "abc-123")
+	logger.InfoContext(ctx, "hello")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("unmarshal log line: %v", err)
+	}
+	if record["request_id"] != "abc-123" {
+		t.Errorf("request_id = %v, want %q", record["request_id"], "abc-123")
+	}
+}
+
+func TestWithRequestIDAttrOmitsWhenAbsent(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(WithRequestIDAttr(slog.NewJSONHandler(&buf, nil)))
+
+	logger.InfoContext(context.Background(), "hello")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("unmarshal log line: %v", err)
+	}
+	if _, ok := record["request_id"]; ok {
+		t.Errorf("request_id = %v, want absent", record["request_id"])
+	}
+}
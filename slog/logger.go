@@ -0,0 +1,87 @@
+// Package slog provides a request logger middleware that emits one
+// structured log line per request via log/slog, correlated with the
+// request ID produced by requestid.RequestIDInjector.
+package slog
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Home24/Base-Go/Godeps/_workspace/src/github.com/codegangsta/negroni"
+
+	requestid "github.com/urakozz/go-requestid-middleware"
+)
+
+type loggerMiddleware struct {
+	logger *slog.Logger
+}
+
+// NewLoggerMiddleware returns a negroni.Handler that must be mounted after
+// requestid.RequestIDInjector. For every request it emits one structured
+// log line on logger containing request_id, method, proto, request_uri,
+// status, bytes and duration_ms.
+func NewLoggerMiddleware(logger *slog.Logger) negroni.Handler {
+	return &loggerMiddleware{logger}
+}
+
+func (l *loggerMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	start := time.Now()
+	next(rw, r)
+
+	var status, bytes int
+	if nrw, ok := rw.(negroni.ResponseWriter); ok {
+		status, bytes = nrw.Status(), nrw.Size()
+	}
+
+	l.logger.Info("request",
+		"request_id", requestid.GetRequestID(r),
+		"method", r.Method,
+		"proto", r.Proto,
+		"request_uri", r.RequestURI,
+		"status", status,
+		"bytes", bytes,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+}
+
+// NewHTTPMiddleware adapts NewLoggerMiddleware into a plain
+// func(http.Handler) http.Handler, for routers that don't speak negroni.
+func NewHTTPMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: rw, status: http.StatusOK}
+			next.ServeHTTP(sw, r)
+
+			logger.Info("request",
+				"request_id", requestid.GetRequestID(r),
+				"method", r.Method,
+				"proto", r.Proto,
+				"request_uri", r.RequestURI,
+				"status", sw.status,
+				"bytes", sw.bytes,
+				"duration_ms", time.Since(start).Milliseconds(),
+			)
+		})
+	}
+}
+
+// statusWriter records the status code and bytes written so they can be
+// logged once the handler chain has finished.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
@@ -0,0 +1,45 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	requestid "github.com/urakozz/go-requestid-middleware"
+)
+
+func TestNewHTTPMiddlewareLogsRequest(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	mw := NewHTTPMiddleware(logger)
+	handler := mw(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusTeapot)
+		rw.Write([]byte("hi"))
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	r = r.WithContext(requestid.NewContext(r.Context(), "abc-123"))
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("unmarshal log line: %v", err)
+	}
+
+	if record["request_id"] != "abc-123" {
+		t.Errorf("request_id = %v, want %q", record["request_id"], "abc-123")
+	}
+	if record["method"] != http.MethodGet {
+		t.Errorf("method = %v, want %q", record["method"], http.MethodGet)
+	}
+	if status, ok := record["status"].(float64); !ok || int(status) != http.StatusTeapot {
+		t.Errorf("status = %v, want %d", record["status"], http.StatusTeapot)
+	}
+	if bytesWritten, ok := record["bytes"].(float64); !ok || int(bytesWritten) != 2 {
+		t.Errorf("bytes = %v, want 2", record["bytes"])
+	}
+}
@@ -0,0 +1,154 @@
+package requestid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// TraceparentHeader is the W3C Trace Context header name.
+	// See https://www.w3.org/TR/trace-context/#traceparent-header.
+	TraceparentHeader = "traceparent"
+
+	traceparentVersion = "00"
+)
+
+// defaultTraceparentFallback is the header priority chain sourceTraceparent
+// falls back to when the request carries no valid traceparent.
+var defaultTraceparentFallback = []string{"X-Request-Id", "X-Correlation-ID", DefaultIDHeader}
+
+// Source Traceparent
+type sourceTraceparent struct {
+	fallback  []string
+	validator IDValidator
+}
+
+// NewSourceTraceparent returns an IDSource that extracts the trace-id out of
+// an incoming W3C `traceparent` header (format
+// "00-<32 hex trace-id>-<16 hex span-id>-<2 hex flags>") and falls back, in
+// the given order, to fallback header names when traceparent is missing or
+// fails validation. With no fallback given it uses, in order: X-Request-Id,
+// X-Correlation-ID, DefaultIDHeader. Unlike traceparent itself (whose
+// grammar is already strictly validated by parseTraceparent), fallback
+// header values are client-controlled free text, so each is run through
+// the same IDValidator NewSourceHeader's callers are expected to apply
+// before it is trusted.
+func NewSourceTraceparent(fallback ...string) IDSource {
+	if len(fallback) == 0 {
+		fallback = defaultTraceparentFallback
+	}
+	return &sourceTraceparent{fallback, NewDefaultIDValidator(0)}
+}
+
+func (s *sourceTraceparent) GetID(r *http.Request) string {
+	if id, ok := parseTraceparent(r.Header.Get(TraceparentHeader)); ok {
+		return id
+	}
+	for _, header := range s.fallback {
+		id := r.Header.Get(header)
+		if id == "" {
+			continue
+		}
+		if err := s.validator.Validate(id); err == nil {
+			return id
+		}
+	}
+	return ""
+}
+
+// parseTraceparent validates v against the W3C traceparent grammar and
+// returns the trace-id on success.
+func parseTraceparent(v string) (string, bool) {
+	parts := strings.Split(v, "-")
+	if len(parts) != 4 {
+		return "", false
+	}
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+
+	if version != traceparentVersion {
+		return "", false
+	}
+	if len(traceID) != 32 || len(spanID) != 16 || len(flags) != 2 {
+		return "", false
+	}
+	if !isLowerHex(traceID) || !isLowerHex(spanID) || !isLowerHex(flags) {
+		return "", false
+	}
+	if traceID == strings.Repeat("0", 32) || spanID == strings.Repeat("0", 16) {
+		return "", false
+	}
+
+	return traceID, true
+}
+
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+		case c >= 'a' && c <= 'f':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// IDPostProcessor Traceparent
+type postProcessorTraceparent struct{}
+
+// NewPostProcessorTraceparent returns an IDPostProcessor that writes a fresh
+// `traceparent` response header and mirrors id into the X-Request-Id and
+// X-Correlation-ID response headers so non-OpenTelemetry consumers can
+// still correlate on a plain header. The traceparent's trace-id is id
+// itself when id is already a valid 32-hex-digit trace-id; otherwise (e.g.
+// id came from timestampIDGenerator, a UUID, or KSUID generator) a fresh
+// random trace-id is generated so the emitted header always satisfies the
+// W3C grammar.
+func NewPostProcessorTraceparent() IDPostProcessor {
+	return &postProcessorTraceparent{}
+}
+
+func (p *postProcessorTraceparent) Process(rw http.ResponseWriter, r *http.Request, id string) {
+	traceID := id
+	if !isValidTraceID(traceID) {
+		generated, err := newTraceID()
+		if err != nil {
+			return
+		}
+		traceID = generated
+	}
+
+	spanID, err := newSpanID()
+	if err != nil {
+		return
+	}
+
+	rw.Header().Set(TraceparentHeader, fmt.Sprintf("%s-%s-%s-01", traceparentVersion, traceID, spanID))
+	rw.Header().Set("X-Request-Id", id)
+	rw.Header().Set("X-Correlation-ID", id)
+}
+
+// isValidTraceID reports whether s is a W3C-compliant, non-zero 32-hex-digit
+// trace-id.
+func isValidTraceID(s string) bool {
+	return len(s) == 32 && isLowerHex(s) && s != strings.Repeat("0", 32)
+}
+
+func newTraceID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func newSpanID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
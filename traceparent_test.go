@@ -0,0 +1,115 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	cases := []struct {
+		name    string
+		header  string
+		wantID  string
+		wantOK  bool
+	}{
+		{
+			name:   "valid",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantOK: true,
+		},
+		{"empty", "", "", false},
+		{"wrong field count", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", "", false},
+		{"unsupported version", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "", false},
+		{"short trace-id", "00-4bf92f35-00f067aa0ba902b7-01", "", false},
+		{"uppercase hex", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", "", false},
+		{"all-zero trace-id", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", "", false},
+		{"all-zero span-id", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", "", false},
+		{"non-hex chars", "00-4bf92f3577b34da6a3ce929d0e0e473g-00f067aa0ba902b7-01", "", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			id, ok := parseTraceparent(c.header)
+			if ok != c.wantOK || id != c.wantID {
+				t.Errorf("parseTraceparent(%q) = (%q, %v), want (%q, %v)", c.header, id, ok, c.wantID, c.wantOK)
+			}
+		})
+	}
+}
+
+func TestSourceTraceparentFallback(t *testing.T) {
+	src := NewSourceTraceparent()
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Correlation-ID", "fallback-id")
+	if got := src.GetID(r); got != "fallback-id" {
+		t.Errorf("GetID() = %q, want fallback-id", got)
+	}
+
+	r.Header.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if got := src.GetID(r); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("GetID() = %q, want the traceparent trace-id to take priority", got)
+	}
+}
+
+func TestSourceTraceparentRejectsInvalidFallback(t *testing.T) {
+	src := NewSourceTraceparent()
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Request-Id", "evil\r\nX-Injected: true")
+
+	if got := src.GetID(r); got != "" {
+		t.Errorf("GetID() = %q, want empty string for a fallback header that fails validation", got)
+	}
+}
+
+func TestSourceTraceparentSkipsInvalidFallbackForNextInChain(t *testing.T) {
+	src := NewSourceTraceparent("X-Request-Id", "X-Correlation-ID")
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Request-Id", "evil\r\nX-Injected: true")
+	r.Header.Set("X-Correlation-ID", "valid-id")
+
+	if got := src.GetID(r); got != "valid-id" {
+		t.Errorf("GetID() = %q, want the next valid header in the fallback chain", got)
+	}
+}
+
+var traceparentHeaderPattern = regexp.MustCompile(`^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`)
+
+func TestPostProcessorTraceparentNormalizesNonHexID(t *testing.T) {
+	p := NewPostProcessorTraceparent()
+	rw := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// timestampIDGenerator-shaped id: not 32 hex chars.
+	p.Process(rw, r, "1769481234.567890.a1b2")
+
+	header := rw.Header().Get(TraceparentHeader)
+	if !traceparentHeaderPattern.MatchString(header) {
+		t.Fatalf("traceparent header = %q, want a W3C-compliant value", header)
+	}
+	if rw.Header().Get("X-Request-Id") != "1769481234.567890.a1b2" {
+		t.Errorf("X-Request-Id = %q, want the original id mirrored", rw.Header().Get("X-Request-Id"))
+	}
+}
+
+func TestPostProcessorTraceparentPreservesValidTraceID(t *testing.T) {
+	p := NewPostProcessorTraceparent()
+	rw := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	p.Process(rw, r, traceID)
+
+	header := rw.Header().Get(TraceparentHeader)
+	if !traceparentHeaderPattern.MatchString(header) {
+		t.Fatalf("traceparent header = %q, want a W3C-compliant value", header)
+	}
+	if header[3:35] != traceID {
+		t.Errorf("traceparent trace-id = %q, want %q preserved", header[3:35], traceID)
+	}
+}
@@ -0,0 +1,81 @@
+package requestid
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const defaultMaxIDLength = 128
+
+var defaultIDCharset = regexp.MustCompile(`^[A-Za-z0-9._\-+/=]+$`)
+
+// IDValidator validates a raw ID value extracted by an IDSource before it
+// is trusted and threaded through the rest of the pipeline.
+type IDValidator interface {
+	Validate(id string) error
+}
+
+type defaultIDValidator struct {
+	maxLength int
+	charset   *regexp.Regexp
+}
+
+// NewDefaultIDValidator returns an IDValidator rejecting values that are
+// empty after trimming whitespace, longer than maxLength, or contain
+// characters outside [A-Za-z0-9._-+/=]. maxLength <= 0 uses the default of
+// 128.
+func NewDefaultIDValidator(maxLength int) IDValidator {
+	if maxLength <= 0 {
+		maxLength = defaultMaxIDLength
+	}
+	return &defaultIDValidator{maxLength, defaultIDCharset}
+}
+
+func (v *defaultIDValidator) Validate(id string) error {
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("requestid: id is empty")
+	}
+	if len(id) > v.maxLength {
+		return fmt.Errorf("requestid: id exceeds max length %d", v.maxLength)
+	}
+	if !v.charset.MatchString(id) {
+		return fmt.Errorf("requestid: id contains disallowed characters")
+	}
+	return nil
+}
+
+// Source Validated
+type validatedSource struct {
+	inner     IDSource
+	validator IDValidator
+	onInvalid func(r *http.Request, raw string, err error)
+}
+
+// NewValidatedSource wraps inner so every ID it extracts from the request
+// is checked against v before being trusted. This guards against a
+// malicious client injecting CRLFs, control characters, or an unreasonably
+// long value into a client-controlled header (e.g. X-Command-ID) that
+// would otherwise flow verbatim into logs and response headers. A value
+// that fails validation is discarded: GetID returns "" so
+// requestIDInjector falls through to IDGenerator.Generate() instead of
+// propagating it. If onInvalid is non-nil it is called with the request,
+// the raw rejected value, and the validation error.
+func NewValidatedSource(inner IDSource, v IDValidator, onInvalid func(r *http.Request, raw string, err error)) IDSource {
+	return &validatedSource{inner, v, onInvalid}
+}
+
+func (s *validatedSource) GetID(r *http.Request) string {
+	id := s.inner.GetID(r)
+	if id == "" {
+		return ""
+	}
+	if err := s.validator.Validate(id); err != nil {
+		if s.onInvalid != nil {
+			s.onInvalid(r, id, err)
+		}
+		return ""
+	}
+	return id
+}
@@ -0,0 +1,97 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDefaultIDValidator(t *testing.T) {
+	v := NewDefaultIDValidator(0)
+
+	cases := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{"valid", "abc123._-+/=", false},
+		{"empty", "", true},
+		{"whitespace only", "   ", true},
+		{"crlf injection", "abc\r\nX-Evil: 1", true},
+		{"control char", "abc\x00def", true},
+		{"disallowed char", "abc def", true},
+		{"oversized", strings.Repeat("a", 129), true},
+		{"max length exactly", strings.Repeat("a", 128), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := v.Validate(c.id)
+			if (err != nil) != c.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", c.id, err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefaultIDValidatorCustomMaxLength(t *testing.T) {
+	v := NewDefaultIDValidator(4)
+	if err := v.Validate("abcd"); err != nil {
+		t.Errorf("Validate(\"abcd\") = %v, want nil", err)
+	}
+	if err := v.Validate("abcde"); err == nil {
+		t.Error("Validate(\"abcde\") = nil, want error")
+	}
+}
+
+func TestValidatedSourceFallsThroughOnInvalid(t *testing.T) {
+	var capturedRaw string
+	var capturedErr error
+
+	src := NewValidatedSource(
+		NewSourceHeader(DefaultIDHeader),
+		NewDefaultIDValidator(0),
+		func(r *http.Request, raw string, err error) {
+			capturedRaw, capturedErr = raw, err
+		},
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(DefaultIDHeader, "evil\r\nX-Injected: true")
+
+	if id := src.GetID(r); id != "" {
+		t.Errorf("GetID() = %q, want empty string for invalid input", id)
+	}
+	if capturedErr == nil {
+		t.Error("onInvalid hook was not called")
+	}
+	if capturedRaw != "evil\r\nX-Injected: true" {
+		t.Errorf("onInvalid raw = %q, want the rejected header value", capturedRaw)
+	}
+}
+
+func TestValidatedSourcePassesValidID(t *testing.T) {
+	src := NewValidatedSource(NewSourceHeader(DefaultIDHeader), NewDefaultIDValidator(0), nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(DefaultIDHeader, "abc-123")
+
+	if id := src.GetID(r); id != "abc-123" {
+		t.Errorf("GetID() = %q, want %q", id, "abc-123")
+	}
+}
+
+func TestDefaultPipelineRejectsInjectedHeaderID(t *testing.T) {
+	injector := NewRequestIDInjector(&IDInjectorOptions{})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(DefaultIDHeader, "evil\r\nX-Injected: true")
+	rw := httptest.NewRecorder()
+
+	injector.ServeHTTP(rw, r, func(http.ResponseWriter, *http.Request) {})
+
+	if got := rw.Header().Get(DefaultIDHeader); got == "evil\r\nX-Injected: true" {
+		t.Errorf("response header carried the unvalidated client value verbatim: %q", got)
+	}
+}